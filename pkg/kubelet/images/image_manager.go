@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+	kubeletevents "k8s.io/kubernetes/pkg/kubelet/events"
+)
+
+const (
+	// backOffPeriod is the duration of the first image pull backoff for a
+	// container.
+	backOffPeriod = 10 * time.Second
+	// maxBackOffPeriod caps how long the kubelet will back off between
+	// pulls of the same image for a container.
+	maxBackOffPeriod = 300 * time.Second
+)
+
+// PullFunc pulls image, returning how long the pull took and any error
+// encountered.
+type PullFunc func(ctx context.Context, image string) (time.Duration, error)
+
+// ImageManager pulls container images on the kubelet's behalf, backing off
+// exponentially between repeated pull failures for the same container
+// instead of retrying immediately.
+type ImageManager struct {
+	recorder record.EventRecorder
+	backOff  *flowcontrol.Backoff
+}
+
+// NewImageManager returns an ImageManager that records Events through
+// recorder and paces retries with the kubelet's standard image-pull backoff
+// window, doubling from backOffPeriod up to maxBackOffPeriod.
+func NewImageManager(recorder record.EventRecorder) *ImageManager {
+	Register()
+	return &ImageManager{
+		recorder: recorder,
+		backOff:  flowcontrol.NewBackOff(backOffPeriod, maxBackOffPeriod),
+	}
+}
+
+// EnsureImageExists pulls the image for container in pod via pull, honoring
+// the kubelet's image-pull backoff: once a container's image starts failing
+// to pull, further attempts are spaced out exponentially instead of
+// hammering the registry. Returns ErrImagePullBackOff while the container is
+// in backoff, without calling pull.
+func (m *ImageManager) EnsureImageExists(ctx context.Context, pod *v1.Pod, container *v1.Container, pull PullFunc) error {
+	backOffKey := fmt.Sprintf("%s_%s", pod.UID, container.Name)
+
+	now := m.backOff.Clock.Now()
+	if m.backOff.IsInBackOffSinceUpdate(backOffKey, now) {
+		duration := m.backOff.Get(backOffKey)
+		ImagePullBackOffTotal.WithLabelValues(container.Image, ErrImagePullBackOff.Error()).Inc()
+		ImagePullBackOffDuration.WithLabelValues(container.Image).Observe(duration.Seconds())
+		klog.V(3).InfoS("Back-off pulling image", "pod", klog.KObj(pod), "podUID", pod.UID, "containerName", container.Name, "image", container.Image, "duration", duration.String())
+		m.recorder.Eventf(pod, v1.EventTypeNormal, kubeletevents.BackOffPullImage, "Back-off pulling image %q", container.Image)
+		return ErrImagePullBackOff
+	}
+
+	klog.V(3).InfoS("Pulling image", "pod", klog.KObj(pod), "podUID", pod.UID, "containerName", container.Name, "image", container.Image)
+	m.recorder.Eventf(pod, v1.EventTypeNormal, kubeletevents.PullingImage, "Pulling image %q", container.Image)
+
+	duration, err := pull(ctx, container.Image)
+	if err != nil {
+		m.backOff.Next(backOffKey, m.backOff.Clock.Now())
+		return err
+	}
+
+	ImagePullDuration.WithLabelValues(container.Image).Observe(duration.Seconds())
+	m.backOff.Reset(backOffKey)
+	m.recorder.Eventf(pod, v1.EventTypeNormal, kubeletevents.PulledImage, "Successfully pulled image %q in %s", container.Image, duration)
+	return nil
+}
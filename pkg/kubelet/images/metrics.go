@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const kubeletSubsystem = "kubelet"
+
+var (
+	// ImagePullDuration tracks how long an image pull took, by image.
+	ImagePullDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      kubeletSubsystem,
+			Name:           "image_pull_duration_seconds",
+			Help:           "Duration in seconds to pull an image.",
+			Buckets:        metrics.ExponentialBuckets(0.5, 2, 8),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"image"},
+	)
+
+	// ImagePullBackOffTotal counts every time the kubelet enters backoff
+	// instead of retrying an image pull, by image and reason.
+	ImagePullBackOffTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      kubeletSubsystem,
+			Name:           "image_pull_backoff_total",
+			Help:           "Cumulative count of image pull backoffs, by image and reason.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"image", "reason"},
+	)
+
+	// ImagePullBackOffDuration tracks how long the kubelet backed off before
+	// retrying an image pull, by image.
+	ImagePullBackOffDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      kubeletSubsystem,
+			Name:           "image_pull_backoff_duration_seconds",
+			Help:           "Duration in seconds the kubelet backed off before retrying an image pull.",
+			Buckets:        metrics.ExponentialBuckets(10, 2, 6),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"image"},
+	)
+
+	registerMetrics sync.Once
+)
+
+// Register registers the image-pull metrics with the legacy registry. It is
+// idempotent and safe to call from multiple call sites.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(ImagePullDuration)
+		legacyregistry.MustRegister(ImagePullBackOffTotal)
+		legacyregistry.MustRegister(ImagePullBackOffDuration)
+	})
+}
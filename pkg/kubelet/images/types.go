@@ -0,0 +1,31 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package images pulls container images, reporting the image-pull-related
+// reasons that show up on a Pod or container's status.
+package images
+
+import "errors"
+
+var (
+	// ErrImagePullBackOff is returned, and surfaced on the pod/container
+	// status, while the kubelet is backing off between failed pulls of the
+	// same image.
+	ErrImagePullBackOff = errors.New("ImagePullBackOff")
+	// ErrImagePull is returned when an image pull fails for a reason other
+	// than backoff.
+	ErrImagePull = errors.New("ErrImagePull")
+)
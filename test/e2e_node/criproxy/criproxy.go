@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package criproxy implements a CRI shim that e2e_node tests run in front of
+// the real container runtime so they can inject faults into individual CRI
+// calls without modifying the runtime itself.
+package criproxy
+
+import "sync"
+
+// Names of the CRI RPCs that can be targeted for fault injection. These
+// match the method names reported to Injector, not the full gRPC method
+// path.
+const (
+	PullImage        = "PullImage"
+	ImageStatus      = "ImageStatus"
+	RemoveImage      = "RemoveImage"
+	RunPodSandbox    = "RunPodSandbox"
+	StopPodSandbox   = "StopPodSandbox"
+	RemovePodSandbox = "RemovePodSandbox"
+	CreateContainer  = "CreateContainer"
+	StartContainer   = "StartContainer"
+	StopContainer    = "StopContainer"
+	RemoveContainer  = "RemoveContainer"
+)
+
+// Injector is called with the name of a CRI RPC before the proxy forwards it
+// to the real runtime. A non-nil error is returned to the caller instead of
+// forwarding the call.
+type Injector func(apiName string) error
+
+// RemoteRuntime holds the Injector that the CRI proxy consults, through
+// Inject, before forwarding each call so tests can simulate faults; it does
+// not itself hold a delegate runtime endpoint or forward calls. The
+// installed Injector can be replaced or cleared at any time; concurrent CRI
+// calls always see either the old or the new one, never a partial update.
+type RemoteRuntime struct {
+	mu       sync.RWMutex
+	injector Injector
+}
+
+// SetInjector atomically installs injector, replacing whatever was
+// previously installed. A nil injector means every call is forwarded
+// unmodified.
+func (r *RemoteRuntime) SetInjector(injector Injector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.injector = injector
+}
+
+// ResetInjector clears the installed injector so every call is forwarded
+// unmodified.
+func (r *RemoteRuntime) ResetInjector() {
+	r.SetInjector(nil)
+}
+
+// InstallProfile atomically installs profile's faults as the active
+// injector.
+func (r *RemoteRuntime) InstallProfile(profile *FaultInjectionProfile) {
+	r.SetInjector(profile.Injector())
+}
+
+// Inject runs the installed injector, if any, for the named CRI call.
+func (r *RemoteRuntime) Inject(apiName string) error {
+	r.mu.RLock()
+	injector := r.injector
+	r.mu.RUnlock()
+	if injector == nil {
+		return nil
+	}
+	return injector(apiName)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package criproxy
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScriptedCall is one entry in an ordered fault-injection script. The Nth
+// invocation of the scripted method sleeps for Delay and then returns Err;
+// once the script is exhausted, every further call repeats the last entry.
+type ScriptedCall struct {
+	Delay time.Duration
+	Err   error
+}
+
+// methodFault is the fault-injection configuration for a single CRI RPC. All
+// fields are only ever written through FaultInjectionProfile's With*
+// builders, and calls counter is guarded by mu so concurrent invocations of
+// the same method advance it consistently.
+type methodFault struct {
+	mu sync.Mutex
+
+	fixedDelay time.Duration
+
+	exponentialBase time.Duration
+	exponentialMax  time.Duration
+
+	errorRate float64
+	errorCode codes.Code
+
+	failCalls int
+
+	script []ScriptedCall
+
+	calls int
+}
+
+// apply advances the call counter under mu and then applies the configured
+// fault outside the lock, so concurrent calls to the same method each sleep
+// their own configured delay instead of serializing behind one another; only
+// the counter needs to be consistent across callers.
+func (m *methodFault) apply(apiName string) error {
+	m.mu.Lock()
+	m.calls++
+	call := m.calls
+	script := m.script
+	failCalls := m.failCalls
+	errorCode := m.errorCode
+	exponentialBase := m.exponentialBase
+	exponentialMax := m.exponentialMax
+	fixedDelay := m.fixedDelay
+	errorRate := m.errorRate
+	m.mu.Unlock()
+
+	if len(script) > 0 {
+		idx := call - 1
+		if idx >= len(script) {
+			idx = len(script) - 1
+		}
+		entry := script[idx]
+		if entry.Delay > 0 {
+			time.Sleep(entry.Delay)
+		}
+		return entry.Err
+	}
+
+	if failCalls > 0 && call <= failCalls {
+		return status.Errorf(errorCode, "%s: injected failure (call %d of %d)", apiName, call, failCalls)
+	}
+
+	switch {
+	case exponentialBase > 0:
+		delay := exponentialBase
+		for i := 1; i < call && (exponentialMax <= 0 || delay < exponentialMax); i++ {
+			delay *= 2
+		}
+		if exponentialMax > 0 && delay > exponentialMax {
+			delay = exponentialMax
+		}
+		time.Sleep(delay)
+	case fixedDelay > 0:
+		time.Sleep(fixedDelay)
+	}
+
+	if errorRate > 0 && rand.Float64() < errorRate {
+		return status.Errorf(errorCode, "%s: injected error (rate %.2f)", apiName, errorRate)
+	}
+
+	return nil
+}
+
+// FaultInjectionProfile is a declarative description of the faults to
+// inject into individual CRI RPCs, built up per method with the With*
+// methods and installed atomically on a RemoteRuntime with InstallProfile.
+// A FaultInjectionProfile is safe for concurrent use once installed, so
+// parallel container operations observe a consistent set of faults.
+type FaultInjectionProfile struct {
+	mu      sync.Mutex
+	methods map[string]*methodFault
+}
+
+// NewFaultInjectionProfile returns an empty profile. Configure it with the
+// With* methods before installing it on a RemoteRuntime.
+func NewFaultInjectionProfile() *FaultInjectionProfile {
+	return &FaultInjectionProfile{methods: make(map[string]*methodFault)}
+}
+
+func (p *FaultInjectionProfile) fault(apiName string) *methodFault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	m, ok := p.methods[apiName]
+	if !ok {
+		m = &methodFault{errorCode: codes.Unknown}
+		p.methods[apiName] = m
+	}
+	return m
+}
+
+// WithDelay adds a fixed delay before every call to apiName.
+func (p *FaultInjectionProfile) WithDelay(apiName string, delay time.Duration) *FaultInjectionProfile {
+	p.fault(apiName).fixedDelay = delay
+	return p
+}
+
+// WithExponentialDelay delays calls to apiName starting at base and doubling
+// on every subsequent call, capped at max (a max of 0 means uncapped).
+func (p *FaultInjectionProfile) WithExponentialDelay(apiName string, base, max time.Duration) *FaultInjectionProfile {
+	m := p.fault(apiName)
+	m.exponentialBase = base
+	m.exponentialMax = max
+	return p
+}
+
+// WithErrorRate fails calls to apiName with code at the given probability
+// (0-1), independent of how many times the method has been called. The
+// method has one error code, shared with WithFailThenSucceed; whichever of
+// the two was called last sets it.
+func (p *FaultInjectionProfile) WithErrorRate(apiName string, rate float64, code codes.Code) *FaultInjectionProfile {
+	m := p.fault(apiName)
+	m.errorRate = rate
+	m.errorCode = code
+	return p
+}
+
+// WithFailThenSucceed fails the first n calls to apiName with code, then
+// forwards every call after. The method has one error code, shared with
+// WithErrorRate; whichever of the two was called last sets it.
+func (p *FaultInjectionProfile) WithFailThenSucceed(apiName string, n int, code codes.Code) *FaultInjectionProfile {
+	m := p.fault(apiName)
+	m.failCalls = n
+	m.errorCode = code
+	return p
+}
+
+// WithScript replays script in order, advancing by one entry per call to
+// apiName and holding on the final entry once the script is exhausted. A
+// script takes priority over any delay/error configuration set for the same
+// method.
+func (p *FaultInjectionProfile) WithScript(apiName string, script ...ScriptedCall) *FaultInjectionProfile {
+	p.fault(apiName).script = script
+	return p
+}
+
+// Injector returns the Injector that applies this profile's faults. The
+// returned func is safe for concurrent use by multiple CRI calls.
+func (p *FaultInjectionProfile) Injector() Injector {
+	return func(apiName string) error {
+		p.mu.Lock()
+		m, ok := p.methods[apiName]
+		p.mu.Unlock()
+		if !ok {
+			return nil
+		}
+		return m.apply(apiName)
+	}
+}
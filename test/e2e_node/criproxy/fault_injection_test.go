@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package criproxy
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFaultInjectionProfileFailThenSucceed(t *testing.T) {
+	profile := NewFaultInjectionProfile().WithFailThenSucceed(PullImage, 2, codes.Unavailable)
+	injector := profile.Injector()
+
+	for call := 1; call <= 2; call++ {
+		err := injector(PullImage)
+		if err == nil {
+			t.Fatalf("call %d: expected an injected failure, got nil", call)
+		}
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("call %d: got code %v, want %v", call, status.Code(err), codes.Unavailable)
+		}
+	}
+
+	for call := 3; call <= 5; call++ {
+		if err := injector(PullImage); err != nil {
+			t.Fatalf("call %d: expected success after the configured failures, got %v", call, err)
+		}
+	}
+}
+
+func TestFaultInjectionProfileScript(t *testing.T) {
+	errBoom := errors.New("boom")
+	profile := NewFaultInjectionProfile().WithScript(StartContainer,
+		ScriptedCall{Err: errBoom},
+		ScriptedCall{Err: nil},
+		ScriptedCall{Err: errBoom},
+	)
+	injector := profile.Injector()
+
+	want := []error{errBoom, nil, errBoom, errBoom, errBoom}
+	for call, wantErr := range want {
+		gotErr := injector(StartContainer)
+		if !errors.Is(gotErr, wantErr) {
+			t.Fatalf("call %d: got error %v, want %v", call+1, gotErr, wantErr)
+		}
+	}
+}
+
+func TestFaultInjectionProfileExponentialDelay(t *testing.T) {
+	profile := NewFaultInjectionProfile().WithExponentialDelay(CreateContainer, 10*time.Millisecond, 30*time.Millisecond)
+	injector := profile.Injector()
+
+	wantDelays := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond}
+	for call, want := range wantDelays {
+		start := time.Now()
+		if err := injector(CreateContainer); err != nil {
+			t.Fatalf("call %d: unexpected error %v", call+1, err)
+		}
+		if elapsed := time.Since(start); elapsed < want {
+			t.Fatalf("call %d: slept %s, want at least %s", call+1, elapsed, want)
+		}
+	}
+}
+
+func TestFaultInjectionProfileDelayRunsConcurrently(t *testing.T) {
+	const (
+		delay       = 200 * time.Millisecond
+		concurrency = 10
+	)
+	profile := NewFaultInjectionProfile().WithDelay(PullImage, delay)
+	injector := profile.Injector()
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := injector(PullImage); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// If the delay were applied while holding the profile's lock, these
+	// calls would serialize and take concurrency*delay; each call should
+	// instead experience its own delay independently.
+	if elapsed := time.Since(start); elapsed >= concurrency*delay {
+		t.Fatalf("concurrent calls took %s, want well under %s (delay should not serialize)", elapsed, concurrency*delay)
+	}
+}
+
+func TestFaultInjectionProfileErrorRate(t *testing.T) {
+	profile := NewFaultInjectionProfile().WithErrorRate(RemovePodSandbox, 1, codes.Internal)
+	injector := profile.Injector()
+
+	if err := injector(RemovePodSandbox); err == nil || status.Code(err) != codes.Internal {
+		t.Fatalf("got %v, want an injected codes.Internal error", err)
+	}
+
+	// A method with no configured fault is left untouched.
+	if err := injector(ImageStatus); err != nil {
+		t.Fatalf("got %v for an unconfigured method, want nil", err)
+	}
+}
+
+func TestRemoteRuntimeInstallAndResetProfile(t *testing.T) {
+	rt := &RemoteRuntime{}
+	rt.InstallProfile(NewFaultInjectionProfile().WithFailThenSucceed(PullImage, 1, codes.Unknown))
+
+	if err := rt.Inject(PullImage); err == nil {
+		t.Fatal("expected the installed profile's failure on the first call")
+	}
+
+	rt.ResetInjector()
+	if err := rt.Inject(PullImage); err != nil {
+		t.Fatalf("got %v after ResetInjector, want nil", err)
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"k8s.io/kubernetes/test/e2e/framework"
 	e2epod "k8s.io/kubernetes/test/e2e/framework/pod"
 	"k8s.io/kubernetes/test/e2e_node/criproxy"
+	"k8s.io/kubernetes/test/e2e_node/retry"
 	imageutils "k8s.io/kubernetes/test/utils/image"
 	admissionapi "k8s.io/pod-security-admission/api"
 )
@@ -48,19 +49,19 @@ var _ = SIGDescribe(feature.CriProxy, framework.WithSerial(), func() {
 
 	ginkgo.Context("Inject a pull image error exception into the CriProxy", func() {
 		ginkgo.BeforeEach(func() {
-			if err := resetCRIProxyInjector(e2eCriProxy); err != nil {
+			if err := resetCRIProxyInjector(); err != nil {
 				ginkgo.Skip("Skip the test since the CRI Proxy is undefined.")
 			}
 		})
 
 		ginkgo.AfterEach(func() {
-			err := resetCRIProxyInjector(e2eCriProxy)
+			err := resetCRIProxyInjector()
 			framework.ExpectNoError(err)
 		})
 
 		ginkgo.It("Pod failed to start due to an image pull error.", func(ctx context.Context) {
 			expectedErr := fmt.Errorf("PullImage failed")
-			err := addCRIProxyInjector(e2eCriProxy, func(apiName string) error {
+			err := addCRIProxyInjector(func(apiName string) error {
 				if apiName == criproxy.PullImage {
 					return expectedErr
 				}
@@ -77,8 +78,7 @@ var _ = SIGDescribe(feature.CriProxy, framework.WithSerial(), func() {
 			})
 			gomega.Expect(podErr).To(gomega.HaveOccurred())
 
-			eventMsg, err := getFailedToPullImageMsg(ctx, f, pod.Name)
-			framework.ExpectNoError(err)
+			eventMsg := getFailedToPullImageMsg(ctx, f, pod.Name)
 			isExpectedErrMsg := strings.Contains(eventMsg, expectedErr.Error())
 			gomega.Expect(isExpectedErrMsg).To(gomega.BeTrueBecause("we injected an exception into the PullImage interface of the cri proxy"))
 		})
@@ -116,8 +116,7 @@ var _ = SIGDescribe(feature.CriProxy, framework.WithSerial(), func() {
 			})
 			gomega.Expect(podErr).To(gomega.HaveOccurred())
 
-			eventMsg, err := getFailedToPullImageMsg(ctx, f, pod.Name)
-			framework.ExpectNoError(err)
+			eventMsg := getFailedToPullImageMsg(ctx, f, pod.Name)
 			isExpectedErrMsg := strings.Contains(eventMsg, expectedErr.Error())
 			gomega.Expect(isExpectedErrMsg).To(gomega.BeTrueBecause("we injected an exception into the PullImage interface of the cri proxy"))
 
@@ -131,119 +130,97 @@ var _ = SIGDescribe(feature.CriProxy, framework.WithSerial(), func() {
 			podErr = e2epod.WaitForPodRunningInNamespace(ctx, f.ClientSet, pod)
 			framework.ExpectNoError(podErr)
 
-			// Parse observed backoffs (TODO: don't use Events, but logs) and compare to expectations
-			durations, err := getImageBackOffDurations(ctx, f, pod.Name)
+			// Parse the observed backoffs from the kubelet log (Events are
+			// lossy: the apiserver coalesces repeats to the most recent
+			// occurrence) and assert the sequence follows the kubelet's
+			// base * 2^n growth, up to its max backoff cap.
+			attempts, err := getImageBackOffDurations(ctx, pod, pod.Name)
 			framework.ExpectNoError(err)
-			gomega.Expect(durations).Error().ShouldNot(gomega.BeNil(), "Should have observed backoffs in Pod event log")
-			gomega.Expect(durations[0]).Should(gomega.BeNumerically("~", time.Duration(10*time.Second), time.Duration(2*time.Second)))
-			// TODO: and check the next set of durations are 2x, etc
+			gomega.Expect(attempts).ShouldNot(gomega.BeEmpty(), "should have observed backoffs in the kubelet log")
+
+			const (
+				baseBackOff = 10 * time.Second
+				maxBackOff  = 300 * time.Second
+				jitter      = 2 * time.Second
+			)
+			for _, a := range attempts {
+				expected := baseBackOff * time.Duration(1<<uint(a.attempt-1))
+				if expected > maxBackOff {
+					expected = maxBackOff
+				}
+				gomega.Expect(a.duration).Should(gomega.BeNumerically("~", expected, jitter),
+					"attempt %d should back off ~%s", a.attempt, expected)
+			}
 
+			// Cross-check against the kubelet's own counters: Events and log
+			// lines can both be lost to rotation, but the metrics are exact.
+			metrics, err := grabImagePullMetrics(ctx, f)
+			framework.ExpectNoError(err)
+			image := imageutils.GetPauseImageName()
+			backOffCount, err := metrics.BackOffTotal(image, images.ErrImagePullBackOff.Error())
+			framework.ExpectNoError(err)
+			gomega.Expect(backOffCount).Should(gomega.BeNumerically(">=", float64(len(attempts))),
+				"kubelet_image_pull_backoff_total should record at least as many backoffs as observed in the kubelet log")
 		})
 	})
 
 	ginkgo.Context("Inject a pull image timeout exception into the CriProxy", func() {
 		ginkgo.BeforeEach(func() {
-			if err := resetCRIProxyInjector(e2eCriProxy); err != nil {
+			if err := resetCRIProxyInjector(); err != nil {
 				ginkgo.Skip("Skip the test since the CRI Proxy is undefined.")
 			}
 		})
 
 		ginkgo.AfterEach(func() {
-			err := resetCRIProxyInjector(e2eCriProxy)
+			err := resetCRIProxyInjector()
 			framework.ExpectNoError(err)
 		})
 
 		ginkgo.It("Image pull time exceeded 10 seconds", func(ctx context.Context) {
 			const delayTime = 10 * time.Second
-			err := addCRIProxyInjector(e2eCriProxy, func(apiName string) error {
-				if apiName == criproxy.PullImage {
-					time.Sleep(10 * time.Second)
-				}
-				return nil
-			})
+			profile := criproxy.NewFaultInjectionProfile().WithDelay(criproxy.PullImage, delayTime)
+			err := addCRIProxyFaultProfile(profile)
 			framework.ExpectNoError(err)
 
 			pod := e2epod.NewPodClient(f).Create(ctx, newPullImageAlwaysPod())
 			podErr := e2epod.WaitForPodRunningInNamespace(ctx, f.ClientSet, pod)
 			framework.ExpectNoError(podErr)
 
-			imagePullDuration, err := getPodImagePullDuration(ctx, f, pod.Name)
-			framework.ExpectNoError(err)
+			imagePullDuration := getPodImagePullDuration(ctx, f, pod.Name)
 
 			gomega.Expect(imagePullDuration).To(gomega.BeNumerically(">=", delayTime), "PullImages should take more than 10 seconds")
 		})
 	})
 })
 
-func getFailedToPullImageMsg(ctx context.Context, f *framework.Framework, podName string) (string, error) {
-	events, err := f.ClientSet.CoreV1().Events(f.Namespace.Name).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	for _, event := range events.Items {
-		if event.Reason == kubeletevents.FailedToPullImage && event.InvolvedObject.Name == podName {
-			return event.Message, nil
+func getFailedToPullImageMsg(ctx context.Context, f *framework.Framework, podName string) string {
+	get := func(ctx context.Context, name string, _ metav1.GetOptions) (string, error) {
+		events, err := f.ClientSet.CoreV1().Events(f.Namespace.Name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return "", err
 		}
-	}
-
-	return "", fmt.Errorf("failed to find FailedToPullImage event for pod: %s", podName)
-}
-
-func getImageBackOffDurations(ctx context.Context, f *framework.Framework, podName string) ([]time.Duration, error) {
-	events, err := f.ClientSet.CoreV1().Events(f.Namespace.Name).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	var backoffs []time.Duration
-
-	type BackOffRecord struct {
-		initialEventTime  time.Time
-		backoffEventTimes []time.Time
-		duration          time.Duration
-	}
-
-	records := make(map[int]*BackOffRecord)
-	records[0] = &BackOffRecord{}
-	var backoffCount int
-	var pullTime time.Time
-	var r *BackOffRecord
-	// I'm doing this here for events but really it needs to be off kubelet logs or some kind of synchronous watch
-	// Because the events normalize to the latest
-	// But this is the idea
-	for _, event := range events.Items {
-		if event.InvolvedObject.Name == podName {
-			switch event.Reason {
-			case kubeletevents.PullingImage:
-				if !pullTime.IsZero() {
-					if event.FirstTimestamp.Time.After(pullTime) {
-						r = records[backoffCount]
-						r.duration = r.initialEventTime.Sub(r.backoffEventTimes[len(r.backoffEventTimes)-1])
-						backoffs = append(backoffs, r.duration)
-						backoffCount++
-					}
-				}
-				pullTime = event.FirstTimestamp.Time
-				records[backoffCount].initialEventTime = pullTime
-			case kubeletevents.BackOffPullImage:
-				current := records[backoffCount].backoffEventTimes
-				current = append(current, event.FirstTimestamp.Time)
+		for _, event := range events.Items {
+			if event.Reason == kubeletevents.FailedToPullImage && event.InvolvedObject.Name == name {
+				return event.Message, nil
 			}
 		}
+		return "", fmt.Errorf("no FailedToPullImage event for pod %s yet", name)
 	}
-	return backoffs, nil
+	return retry.GetK8sObjectWithRetry(ctx, retry.HandleRetry(get), podName, metav1.GetOptions{})
 }
 
-func getPodImagePullDuration(ctx context.Context, f *framework.Framework, podName string) (time.Duration, error) {
-	events, err := f.ClientSet.CoreV1().Events(f.Namespace.Name).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return 0, err
-	}
+func getPodImagePullDuration(ctx context.Context, f *framework.Framework, podName string) time.Duration {
+	get := func(ctx context.Context, name string, _ metav1.GetOptions) (time.Duration, error) {
+		events, err := f.ClientSet.CoreV1().Events(f.Namespace.Name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return 0, err
+		}
 
-	var startTime, endTime time.Time
-	for _, event := range events.Items {
-		if event.InvolvedObject.Name == podName {
+		var startTime, endTime time.Time
+		for _, event := range events.Items {
+			if event.InvolvedObject.Name != name {
+				continue
+			}
 			switch event.Reason {
 			case kubeletevents.PullingImage:
 				startTime = event.FirstTimestamp.Time
@@ -251,13 +228,12 @@ func getPodImagePullDuration(ctx context.Context, f *framework.Framework, podNam
 				endTime = event.FirstTimestamp.Time
 			}
 		}
+		if startTime.IsZero() || endTime.IsZero() {
+			return 0, fmt.Errorf("still waiting for both PullingImage and PulledImage events for pod %s", name)
+		}
+		return endTime.Sub(startTime), nil
 	}
-
-	if startTime.IsZero() || endTime.IsZero() {
-		return 0, fmt.Errorf("failed to find both PullingImage and PulledImage events for pod: %s", podName)
-	}
-
-	return endTime.Sub(startTime), nil
+	return retry.GetK8sObjectWithRetry(ctx, retry.HandleRetry(get), podName, metav1.GetOptions{})
 }
 
 func newPullImageAlwaysPod() *v1.Pod {
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2enode
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/common/expfmt"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/kubernetes/test/e2e/framework"
+)
+
+// Names of the kubelet image-pull metrics this helper exposes typed
+// accessors for.
+const (
+	imagePullDurationMetric  = "kubelet_image_pull_duration_seconds"
+	imagePullBackOffTotal    = "kubelet_image_pull_backoff_total"
+	imagePullBackOffDuration = "kubelet_image_pull_backoff_duration_seconds"
+)
+
+// ImagePullMetrics is a snapshot of the image-pull-related samples scraped
+// from the kubelet's Prometheus /metrics endpoint.
+type ImagePullMetrics struct {
+	families map[string]*dto.MetricFamily
+}
+
+// grabImagePullMetrics scrapes the kubelet's authenticated /metrics
+// endpoint, via the apiserver's node proxy subresource, and returns the
+// parsed families.
+func grabImagePullMetrics(ctx context.Context, f *framework.Framework) (*ImagePullMetrics, error) {
+	data, err := f.ClientSet.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(framework.TestContext.NodeName).
+		SubResource("proxy").
+		Suffix("metrics").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape kubelet metrics: %w", err)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bufio.NewReader(strings.NewReader(string(data))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubelet metrics: %w", err)
+	}
+	return &ImagePullMetrics{families: families}, nil
+}
+
+// BackOffTotal returns the cumulative count of image-pull backoffs recorded
+// for image with the given reason (e.g. images.ErrImagePullBackOff.Error()).
+func (m *ImagePullMetrics) BackOffTotal(image, reason string) (float64, error) {
+	metric, err := m.sample(imagePullBackOffTotal, map[string]string{"image": image, "reason": reason})
+	if err != nil {
+		return 0, err
+	}
+	return metric.GetCounter().GetValue(), nil
+}
+
+// BackOffDurationSum returns the sum of the backoff durations observed for
+// image. The kubelet observes the current backoff window each time it
+// refuses a pull, so a window that refuses several pulls is counted once
+// per refusal.
+func (m *ImagePullMetrics) BackOffDurationSum(image string) (float64, error) {
+	metric, err := m.sample(imagePullBackOffDuration, map[string]string{"image": image})
+	if err != nil {
+		return 0, err
+	}
+	return metric.GetHistogram().GetSampleSum(), nil
+}
+
+// PullDurationSum returns the cumulative seconds the kubelet has spent
+// successfully pulling image.
+func (m *ImagePullMetrics) PullDurationSum(image string) (float64, error) {
+	metric, err := m.sample(imagePullDurationMetric, map[string]string{"image": image})
+	if err != nil {
+		return 0, err
+	}
+	return metric.GetHistogram().GetSampleSum(), nil
+}
+
+func (m *ImagePullMetrics) sample(name string, labels map[string]string) (*dto.Metric, error) {
+	family, ok := m.families[name]
+	if !ok {
+		return nil, fmt.Errorf("metric %q not found in kubelet /metrics", name)
+	}
+	for _, metric := range family.GetMetric() {
+		if labelsMatch(metric.GetLabel(), labels) {
+			return metric, nil
+		}
+	}
+	return nil, fmt.Errorf("no %q sample matching labels %v", name, labels)
+}
+
+func labelsMatch(pairs []*dto.LabelPair, want map[string]string) bool {
+	got := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		got[p.GetName()] = p.GetValue()
+	}
+	for k, v := range want {
+		if got[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2enode
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// kubeletLogPath is where the kubelet writes its log when the node isn't
+// running systemd; when it is, getKubeletLogs reads the same records out of
+// the journal instead.
+const kubeletLogPath = "/var/log/kubelet.log"
+
+// imageBackOffAttempt is one image-pull retry the kubelet logged: which
+// attempt it was for the container, and how long the kubelet backed off
+// before making it.
+type imageBackOffAttempt struct {
+	attempt  int
+	duration time.Duration
+}
+
+var (
+	backOffImageLogRE = regexp.MustCompile(`"Back-off pulling image".*\bpodUID="(?P<uid>[^"]+)".*\bcontainerName="(?P<container>[^"]+)".*\bduration="(?P<duration>[^"]+)"`)
+	uidIdx            = backOffImageLogRE.SubexpIndex("uid")
+	containerIdx      = backOffImageLogRE.SubexpIndex("container")
+	durationIdx       = backOffImageLogRE.SubexpIndex("duration")
+
+	pullingImageLogRE = regexp.MustCompile(`"Pulling image".*\bpodUID="([^"]+)".*\bcontainerName="([^"]+)"`)
+)
+
+// getKubeletLogs returns the kubelet's log lines, reading the journal when
+// the node runs systemd and the flat log file otherwise.
+func getKubeletLogs(ctx context.Context) ([]string, error) {
+	var cmd *exec.Cmd
+	if _, err := exec.LookPath("journalctl"); err == nil {
+		cmd = exec.CommandContext(ctx, "journalctl", "-u", "kubelet", "--no-pager")
+	} else {
+		cmd = exec.CommandContext(ctx, "cat", kubeletLogPath)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubelet logs: %w", err)
+	}
+	return strings.Split(string(out), "\n"), nil
+}
+
+// getImageBackOffDurations scrapes the kubelet log for the "Pulling image"
+// and "Back-off pulling image" records for pod's containerName (matched by
+// pod UID, so log rotation or pod name reuse can't cross-match) and returns
+// the backoffs as an ordered (attempt, duration) sequence. Each backoff
+// window between two pulls is one attempt, however many refused retries the
+// kubelet logged within it. Unlike Events, which the apiserver coalesces to
+// the most recent occurrence, every logged window is represented exactly
+// once.
+func getImageBackOffDurations(ctx context.Context, pod *v1.Pod, containerName string) ([]imageBackOffAttempt, error) {
+	lines, err := getKubeletLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts []imageBackOffAttempt
+	newWindow := true
+	for _, line := range lines {
+		if m := pullingImageLogRE.FindStringSubmatch(line); m != nil {
+			if types.UID(m[1]) == pod.UID && m[2] == containerName {
+				newWindow = true
+			}
+			continue
+		}
+		m := backOffImageLogRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if types.UID(m[uidIdx]) != pod.UID || m[containerIdx] != containerName {
+			continue
+		}
+		if !newWindow {
+			continue
+		}
+		newWindow = false
+		duration, err := time.ParseDuration(m[durationIdx])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse backoff duration from kubelet log line %q: %w", line, err)
+		}
+		attempts = append(attempts, imageBackOffAttempt{attempt: len(attempts) + 1, duration: duration})
+	}
+	return attempts, nil
+}
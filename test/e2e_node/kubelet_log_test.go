@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2enode
+
+import (
+	"testing"
+	"time"
+)
+
+// This pins backOffImageLogRE to the literal message klog.InfoS renders for
+// the "Back-off pulling image" call in
+// pkg/kubelet/images.ImageManager.EnsureImageExists: klog quotes the pod,
+// podUID, containerName and image key-values because they're strings, and
+// quotes duration too because EnsureImageExists passes it pre-stringified
+// with time.Duration.String() rather than the raw duration value.
+func TestBackOffImageLogRE(t *testing.T) {
+	const line = `I0726 12:00:03.123456    1234 image_manager.go:76] "Back-off pulling image" pod="default/cri-proxy-test-abc" podUID="f47ac10b-58cc-4372-a567-0e02b2c3d479" containerName="cri-proxy-test-abc" image="registry.k8s.io/pause:3.9" duration="10s"`
+
+	tests := []struct {
+		name        string
+		line        string
+		wantMatch   bool
+		wantUID     string
+		wantName    string
+		wantBackoff time.Duration
+	}{
+		{
+			name:        "matches a real Back-off pulling image line",
+			line:        line,
+			wantMatch:   true,
+			wantUID:     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			wantName:    "cri-proxy-test-abc",
+			wantBackoff: 10 * time.Second,
+		},
+		{
+			name:      "does not match a Pulling image line",
+			line:      `I0726 12:00:03.000000    1234 image_manager.go:81] "Pulling image" pod="default/cri-proxy-test-abc" podUID="f47ac10b-58cc-4372-a567-0e02b2c3d479" containerName="cri-proxy-test-abc" image="registry.k8s.io/pause:3.9"`,
+			wantMatch: false,
+		},
+		{
+			name:      "does not match an unrelated log line",
+			line:      `I0726 12:00:03.000000    1234 kubelet.go:2000] "SyncLoop (housekeeping)"`,
+			wantMatch: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := backOffImageLogRE.FindStringSubmatch(tc.line)
+			if matched := m != nil; matched != tc.wantMatch {
+				t.Fatalf("got match=%v, want %v (line: %q)", matched, tc.wantMatch, tc.line)
+			}
+			if !tc.wantMatch {
+				return
+			}
+			if got := m[uidIdx]; got != tc.wantUID {
+				t.Errorf("got podUID %q, want %q", got, tc.wantUID)
+			}
+			if got := m[containerIdx]; got != tc.wantName {
+				t.Errorf("got containerName %q, want %q", got, tc.wantName)
+			}
+			gotBackoff, err := time.ParseDuration(m[durationIdx])
+			if err != nil {
+				t.Fatalf("failed to parse captured duration %q: %v", m[durationIdx], err)
+			}
+			if gotBackoff != tc.wantBackoff {
+				t.Errorf("got duration %s, want %s", gotBackoff, tc.wantBackoff)
+			}
+		})
+	}
+}
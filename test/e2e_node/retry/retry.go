@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retry provides small, typed helpers for polling Kubernetes
+// objects in e2e_node tests, so tests stop open-coding their own
+// WaitForPodCondition-style loops and get uniform flake diagnostics.
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/test/e2e/framework"
+)
+
+const (
+	// DefaultTimeout bounds how long GetK8sObjectWithRetry waits for get to
+	// stop failing before it gives up.
+	DefaultTimeout = 1 * time.Minute
+	// PollingInterval is how often GetK8sObjectWithRetry retries get.
+	PollingInterval = 2 * time.Second
+)
+
+// K8sGetFunc retrieves a single object named name, in the shape the typed
+// clientset getters already return it, e.g. clientset.CoreV1().Pods(ns).Get.
+type K8sGetFunc[T any] func(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+
+// GetK8sObjectWithRetry polls get with gomega.Eventually until it succeeds
+// or DefaultTimeout elapses, and returns the last retrieved object. A test
+// failure is reported through gomega, so callers get the same diagnostics
+// as any other Eventually-based assertion.
+func GetK8sObjectWithRetry[T any](ctx context.Context, get K8sGetFunc[T], name string, opts metav1.GetOptions) T {
+	var obj T
+	gomega.Eventually(ctx, func() error {
+		o, err := get(ctx, name, opts)
+		if err != nil {
+			return err
+		}
+		obj = o
+		return nil
+	}).WithTimeout(DefaultTimeout).WithPolling(PollingInterval).Should(gomega.Succeed(), "getting %q", name)
+	return obj
+}
+
+// IgnoreNotFound adapts get so a NotFound error counts as success: polling
+// stops and get's returned object (typically the zero value) is used as is,
+// instead of waiting for the object to appear.
+func IgnoreNotFound[T any](get K8sGetFunc[T]) K8sGetFunc[T] {
+	return func(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+		obj, err := get(ctx, name, opts)
+		if apierrors.IsNotFound(err) {
+			err = nil
+		}
+		return obj, err
+	}
+}
+
+// HandleRetry wraps get to log each failed attempt before retrying, so a
+// flake's full attempt history shows up in the test log instead of only the
+// final error.
+func HandleRetry[T any](get K8sGetFunc[T]) K8sGetFunc[T] {
+	return func(ctx context.Context, name string, opts metav1.GetOptions) (T, error) {
+		obj, err := get(ctx, name, opts)
+		if err != nil {
+			framework.Logf("retrying get of %q: %v", name, err)
+		}
+		return obj, err
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2enode
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/test/e2e_node/criproxy"
+)
+
+// e2eCriProxy is the CRI proxy the node e2e framework starts in front of the
+// container runtime, or nil when the node wasn't started with one.
+var e2eCriProxy *criproxy.RemoteRuntime
+
+// addCRIProxyInjector installs injector as the e2e_node CRI proxy's fault
+// injector, replacing whatever was previously installed.
+func addCRIProxyInjector(injector criproxy.Injector) error {
+	if e2eCriProxy == nil {
+		return fmt.Errorf("the CRI proxy is not running")
+	}
+	e2eCriProxy.SetInjector(injector)
+	return nil
+}
+
+// addCRIProxyFaultProfile installs profile's faults on the e2e_node CRI
+// proxy, replacing whatever injector was previously installed.
+func addCRIProxyFaultProfile(profile *criproxy.FaultInjectionProfile) error {
+	if e2eCriProxy == nil {
+		return fmt.Errorf("the CRI proxy is not running")
+	}
+	e2eCriProxy.InstallProfile(profile)
+	return nil
+}
+
+// resetCRIProxyInjector clears any fault injector installed on the e2e_node
+// CRI proxy.
+func resetCRIProxyInjector() error {
+	if e2eCriProxy == nil {
+		return fmt.Errorf("the CRI proxy is not running")
+	}
+	e2eCriProxy.ResetInjector()
+	return nil
+}